@@ -0,0 +1,38 @@
+package mixer
+
+import "pipelined.dev/signal"
+
+// defaultBus is the name of the bus backing Source, so a Mixer used
+// without calling Bus behaves exactly as a single-bus mixer always did.
+const defaultBus = "main"
+
+// busState tracks, for a single named output bus, the per-input frame
+// chains that still need to be summed and the channel the mixed result is
+// delivered on.
+type busState struct {
+	head   *frame
+	frames []*frame
+	output chan signal.Floating
+	meter  *Meter
+}
+
+func newBusState(registeredInputs int) *busState {
+	head := &frame{expected: registeredInputs}
+	frames := make([]*frame, registeredInputs)
+	for i := range frames {
+		frames[i] = head
+	}
+	return &busState{
+		head:   head,
+		frames: frames,
+		output: make(chan signal.Floating, 1),
+		meter:  newMeter(),
+	}
+}
+
+// addInput registers a new input with this bus, so that future frames
+// expect a contribution from it too.
+func (b *busState) addInput() {
+	b.frames = append(b.frames, b.head)
+	b.head.expected++
+}
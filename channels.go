@@ -0,0 +1,77 @@
+package mixer
+
+import "pipelined.dev/signal"
+
+// ChannelMatrix holds per-output-channel weights applied to each input
+// channel: Matrix[out][in] is the gain with which input channel in
+// contributes to output channel out. len(Matrix) must equal the number of
+// output channels.
+type ChannelMatrix [][]float64
+
+// WithChannelMatrix configures the mixer to adapt any sink whose channel
+// count differs from the mixer's with m, instead of rejecting it with
+// ErrDifferentChannels. The same matrix is used regardless of the sink's
+// channel count, so m must have one row per mixer channel and one column
+// per channel the adapted sinks provide.
+func WithChannelMatrix(m ChannelMatrix) Option {
+	return func(mx *Mixer) {
+		mx.channelMatrix = m
+	}
+}
+
+// defaultChannelMatrix builds the common mono<->stereo adaptations when no
+// explicit matrix was configured: mono input copies its single channel to
+// every output channel, stereo input folds down to mono by averaging.
+func defaultChannelMatrix(in, out int) ChannelMatrix {
+	switch {
+	case in == 1 && out > 1:
+		m := make(ChannelMatrix, out)
+		for o := range m {
+			m[o] = []float64{1}
+		}
+		return m
+	case in == 2 && out == 1:
+		return ChannelMatrix{{0.5, 0.5}}
+	default:
+		return nil
+	}
+}
+
+// channelAdapter converts a buffer with numChannels(in) channels into a
+// freshly pool-allocated buffer with numChannels(out) channels, so that
+// every buffer reaching the mixer goroutine already has the mixer's
+// channel count.
+type channelAdapter struct {
+	pool   *signal.Pool
+	in     int
+	out    int
+	matrix ChannelMatrix
+}
+
+func newChannelAdapter(pool *signal.Pool, in, out int, matrix ChannelMatrix) *channelAdapter {
+	if matrix == nil {
+		matrix = defaultChannelMatrix(in, out)
+	}
+	return &channelAdapter{pool: pool, in: in, out: out, matrix: matrix}
+}
+
+// Adapt returns the channel-adapted buffer. If no matrix could be resolved
+// for the in/out combination, it returns the input buffer unchanged.
+func (a *channelAdapter) Adapt(in signal.Floating) signal.Floating {
+	if a.matrix == nil {
+		return in
+	}
+	frames := in.Length() / a.in
+	out := a.pool.GetFloat64().Slice(0, 0)
+	for f := 0; f < frames; f++ {
+		for o := 0; o < a.out; o++ {
+			row := a.matrix[o]
+			var sum float64
+			for i := 0; i < a.in && i < len(row); i++ {
+				sum += row[i] * in.Sample(f*a.in+i)
+			}
+			out = out.AppendSample(sum)
+		}
+	}
+	return out
+}
@@ -0,0 +1,166 @@
+package mixer
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"pipelined.dev/pipe"
+	"pipelined.dev/signal"
+)
+
+// Seeker is implemented by sources that can seek to an arbitrary sample
+// position. Loop requires it so it can restart src from the beginning
+// between iterations.
+type Seeker interface {
+	Seek(sample int64) error
+}
+
+// SeekableSourceAllocatorFunc is a pipe.SourceAllocatorFunc that also
+// hands back the Seeker for the pipe.Source it just allocated. A plain
+// pipe.Source is a struct of closures with no way to recover the Seeker
+// of the value behind it, so Loop needs this instead of a
+// pipe.SourceAllocatorFunc to restart a source in place.
+type SeekableSourceAllocatorFunc func(bufferSize int) (pipe.Source, Seeker, pipe.SignalProperties, error)
+
+// Take returns a source allocator that yields at most n samples from src,
+// then behaves as if src reached io.EOF even if it has more to give.
+func Take(n int, src pipe.SourceAllocatorFunc) pipe.SourceAllocatorFunc {
+	return func(bufferSize int) (pipe.Source, pipe.SignalProperties, error) {
+		source, props, err := src(bufferSize)
+		if err != nil {
+			return pipe.Source{}, pipe.SignalProperties{}, err
+		}
+		remaining := n
+		return pipe.Source{
+			SourceFunc: func(out signal.Floating) (int, error) {
+				if remaining <= 0 {
+					return 0, io.EOF
+				}
+				read, err := source.SourceFunc(out)
+				if read > remaining {
+					read = remaining
+				}
+				remaining -= read
+				return read, err
+			},
+			FlushFunc: source.FlushFunc,
+		}, props, nil
+	}
+}
+
+// Loop plays src a total of count times, -1 meaning forever, restarting
+// every replay by seeking src's Seeker back to the beginning instead of
+// reallocating it.
+func Loop(count int, src SeekableSourceAllocatorFunc) pipe.SourceAllocatorFunc {
+	return func(bufferSize int) (pipe.Source, pipe.SignalProperties, error) {
+		source, seeker, props, err := src(bufferSize)
+		if err != nil {
+			return pipe.Source{}, pipe.SignalProperties{}, err
+		}
+		remaining := count
+		if remaining > 0 {
+			// the first play below isn't gated by this loop, so reserve
+			// its count upfront; only replays after an EOF decrement
+			// further.
+			remaining--
+		}
+		return pipe.Source{
+			SourceFunc: func(out signal.Floating) (int, error) {
+				read, err := source.SourceFunc(out)
+				for read == 0 && err == io.EOF && remaining != 0 {
+					if remaining > 0 {
+						remaining--
+					}
+					if seekErr := seeker.Seek(0); seekErr != nil {
+						return 0, seekErr
+					}
+					read, err = source.SourceFunc(out)
+				}
+				return read, err
+			},
+			FlushFunc: source.FlushFunc,
+		}, props, nil
+	}
+}
+
+// Seq concatenates srcs back-to-back, switching to the next one as soon
+// as the current one reports io.EOF, and propagating the first non-EOF
+// error encountered.
+func Seq(srcs ...pipe.SourceAllocatorFunc) pipe.SourceAllocatorFunc {
+	return func(bufferSize int) (pipe.Source, pipe.SignalProperties, error) {
+		if len(srcs) == 0 {
+			return pipe.Source{}, pipe.SignalProperties{}, errors.New("mixer: Seq requires at least one source")
+		}
+		idx := 0
+		source, props, err := srcs[0](bufferSize)
+		if err != nil {
+			return pipe.Source{}, pipe.SignalProperties{}, err
+		}
+		return pipe.Source{
+				SourceFunc: func(out signal.Floating) (int, error) {
+					for {
+						read, err := source.SourceFunc(out)
+						if err != io.EOF {
+							return read, err
+						}
+						if read > 0 {
+							return read, nil
+						}
+						idx++
+						if idx >= len(srcs) {
+							return 0, io.EOF
+						}
+						next, _, err := srcs[idx](bufferSize)
+						if err != nil {
+							return 0, err
+						}
+						source = next
+					}
+				},
+				FlushFunc: func(ctx context.Context) error {
+					return source.FlushFunc(ctx)
+				},
+			}, props, nil
+	}
+}
+
+// Combine wires srcs into a fresh Mixer with the given channel count and
+// returns its default bus's source, driving the inner sources on their
+// own goroutine until the returned source is flushed.
+func Combine(channels int, srcs ...pipe.SourceAllocatorFunc) pipe.SourceAllocatorFunc {
+	return func(bufferSize int) (pipe.Source, pipe.SignalProperties, error) {
+		m := New(channels)
+		lines := make([]pipe.Line, 0, len(srcs))
+		for _, src := range srcs {
+			sinkAllocator, _ := m.Sink()
+			line, err := pipe.Routing{Source: src, Sink: sinkAllocator}.Line(bufferSize)
+			if err != nil {
+				return pipe.Source{}, pipe.SignalProperties{}, err
+			}
+			lines = append(lines, line)
+		}
+		sourceAllocator, _ := m.Source()
+		source, props, err := sourceAllocator(bufferSize)
+		if err != nil {
+			return pipe.Source{}, pipe.SignalProperties{}, err
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan error, 1)
+		go func() {
+			done <- pipe.New(ctx, pipe.WithLines(lines...)).Wait()
+		}()
+		return pipe.Source{
+				SourceFunc: source.SourceFunc,
+				FlushFunc: func(flushCtx context.Context) error {
+					err := source.FlushFunc(flushCtx)
+					cancel()
+					if runErr := <-done; runErr != nil && err == nil {
+						err = runErr
+					}
+					return err
+				},
+			}, props, nil
+	}
+}
@@ -0,0 +1,132 @@
+package mixer_test
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"pipelined.dev/mixer"
+	"pipelined.dev/pipe"
+	"pipelined.dev/pipe/mock"
+	"pipelined.dev/signal"
+)
+
+func TestTake(t *testing.T) {
+	const (
+		numChannels = 1
+		bufferSize  = 2
+	)
+	gen := mock.Source{Channels: numChannels, Limit: 8, Value: 0.3}
+	sink := mock.Sink{}
+
+	line, _ := pipe.Routing{
+		Source: mixer.Take(3, gen.Source()),
+		Sink:   sink.Sink(),
+	}.Line(bufferSize)
+
+	err := pipe.New(context.Background(), pipe.WithLines(line)).Wait()
+	assertEqual(t, "error", err, nil)
+
+	result := make([]float64, sink.Values.Len())
+	signal.ReadFloat64(sink.Values, result)
+	assertEqual(t, "result", result, []float64{0.3, 0.3, 0.3})
+}
+
+// seekableSource is a minimal source implementing mixer.Seeker, used to
+// exercise Loop without depending on a Seek-capable source from the pipe
+// package's mock.
+type seekableSource struct {
+	channels int
+	values   []float64
+	pos      int
+}
+
+func (s *seekableSource) allocate(int) (pipe.Source, mixer.Seeker, pipe.SignalProperties, error) {
+	return pipe.Source{
+			SourceFunc: func(out signal.Floating) (int, error) {
+				if s.pos >= len(s.values) {
+					return 0, io.EOF
+				}
+				n := 0
+				for i := 0; i < out.Len() && s.pos < len(s.values); i++ {
+					out.SetSample(i, s.values[s.pos])
+					s.pos++
+					n++
+				}
+				return n, nil
+			},
+			FlushFunc: func(context.Context) error {
+				return nil
+			},
+		}, s, pipe.SignalProperties{Channels: s.channels}, nil
+}
+
+func (s *seekableSource) Seek(sample int64) error {
+	s.pos = int(sample) * s.channels
+	return nil
+}
+
+func TestLoop(t *testing.T) {
+	const (
+		numChannels = 1
+		bufferSize  = 2
+	)
+	src := &seekableSource{channels: numChannels, values: []float64{0.1, 0.2}}
+	sink := mock.Sink{}
+
+	line, _ := pipe.Routing{
+		Source: mixer.Loop(2, src.allocate),
+		Sink:   sink.Sink(),
+	}.Line(bufferSize)
+
+	err := pipe.New(context.Background(), pipe.WithLines(line)).Wait()
+	assertEqual(t, "error", err, nil)
+
+	result := make([]float64, sink.Values.Len())
+	signal.ReadFloat64(sink.Values, result)
+	assertEqual(t, "result", result, []float64{0.1, 0.2, 0.1, 0.2})
+}
+
+func TestCombine(t *testing.T) {
+	const (
+		numChannels = 1
+		bufferSize  = 2
+	)
+	first := mock.Source{Channels: numChannels, Limit: 4, Value: 0.2}
+	second := mock.Source{Channels: numChannels, Limit: 4, Value: 0.4}
+	sink := mock.Sink{}
+
+	line, _ := pipe.Routing{
+		Source: mixer.Combine(numChannels, first.Source(), second.Source()),
+		Sink:   sink.Sink(),
+	}.Line(bufferSize)
+
+	err := pipe.New(context.Background(), pipe.WithLines(line)).Wait()
+	assertEqual(t, "error", err, nil)
+
+	result := make([]float64, sink.Values.Len())
+	signal.ReadFloat64(sink.Values, result)
+	assertEqual(t, "result", result, []float64{0.3, 0.3, 0.3, 0.3})
+}
+
+func TestSeq(t *testing.T) {
+	const (
+		numChannels = 1
+		bufferSize  = 2
+	)
+	first := mock.Source{Channels: numChannels, Limit: 2, Value: 0.1}
+	second := mock.Source{Channels: numChannels, Limit: 2, Value: 0.9}
+	sink := mock.Sink{}
+
+	line, _ := pipe.Routing{
+		Source: mixer.Seq(first.Source(), second.Source()),
+		Sink:   sink.Sink(),
+	}.Line(bufferSize)
+
+	err := pipe.New(context.Background(), pipe.WithLines(line)).Wait()
+	assertEqual(t, "error", err, nil)
+
+	result := make([]float64, sink.Values.Len())
+	signal.ReadFloat64(sink.Values, result)
+	assertEqual(t, "result", result, []float64{0.1, 0.1, 0.9, 0.9})
+}
@@ -0,0 +1,171 @@
+package mixer
+
+import (
+	"math"
+	"sync"
+	"sync/atomic"
+
+	"pipelined.dev/signal"
+)
+
+// SumMode selects how the mixer combines the contributing inputs of a
+// frame into a single output sample.
+type SumMode int
+
+const (
+	// SumAverage divides the sum of contributing inputs by how many of
+	// them were active, matching the mixer's original behaviour. It is
+	// the default.
+	SumAverage SumMode = iota
+	// SumSaturate adds contributing inputs together without dividing,
+	// leaving the result free to exceed the [-1, 1] range.
+	SumSaturate
+	// SumClipHard adds contributing inputs together and clips the result
+	// to [-1, 1].
+	SumClipHard
+)
+
+// WithSumMode selects how overlapping inputs are combined into the mixed
+// output. Averaging is musically wrong once per-input gain automation is
+// in play, since a loud solo input gets quieter the more other inputs are
+// playing alongside it.
+func WithSumMode(mode SumMode) Option {
+	return func(m *Mixer) {
+		m.sumMode = mode
+	}
+}
+
+// Input is a handle to a single sink of a Mixer. Its controls can be
+// updated from any goroutine while the mixer is running. Gain, mute, solo
+// and pan are stored atomically and never block the mixing loop; sends are
+// guarded by a mutex that the mixing loop also takes for every buffer.
+type Input struct {
+	gain   uint64 // math.Float64bits, accessed atomically
+	pan    uint64 // math.Float64bits, accessed atomically
+	muted  uint32 // 0 or 1, accessed atomically
+	soloed uint32 // 0 or 1, accessed atomically
+
+	meter *Meter
+
+	sendsMu sync.Mutex
+	sends   map[string]float64
+}
+
+func newInput() *Input {
+	in := &Input{meter: newMeter()}
+	in.SetGain(1)
+	return in
+}
+
+// Meter returns the Peak/RMS meter tracking this input's signal, as it
+// enters the mix.
+func (in *Input) Meter() *Meter {
+	return in.meter
+}
+
+// SetGain sets the linear gain applied to every sample of this input
+// before it is summed into the mix. 1 is unity gain, 0 is silence.
+func (in *Input) SetGain(gain float64) {
+	atomic.StoreUint64(&in.gain, math.Float64bits(gain))
+}
+
+func (in *Input) gain_() float64 {
+	return math.Float64frombits(atomic.LoadUint64(&in.gain))
+}
+
+// SetMute mutes or unmutes this input.
+func (in *Input) SetMute(mute bool) {
+	atomic.StoreUint32(&in.muted, boolToUint32(mute))
+}
+
+func (in *Input) isMuted() bool {
+	return atomic.LoadUint32(&in.muted) != 0
+}
+
+// SetSolo solos or unsolos this input. While at least one input of the
+// mixer is soloed, every non-soloed input is treated as muted.
+func (in *Input) SetSolo(solo bool) {
+	atomic.StoreUint32(&in.soloed, boolToUint32(solo))
+}
+
+func (in *Input) isSoloed() bool {
+	return atomic.LoadUint32(&in.soloed) != 0
+}
+
+// SetPan sets the stereo pan position in [-1, 1], where -1 is hard left,
+// 0 is center and 1 is hard right. Pan only has an effect on mixers with
+// exactly two channels.
+func (in *Input) SetPan(pan float64) {
+	atomic.StoreUint64(&in.pan, math.Float64bits(pan))
+}
+
+func (in *Input) pan_() float64 {
+	return math.Float64frombits(atomic.LoadUint64(&in.pan))
+}
+
+// SetSend sets how much of this input reaches bus, as a linear gain. A
+// sink sends to "main" at unity gain and to every other bus at silence
+// until SetSend says otherwise.
+func (in *Input) SetSend(bus string, gain float64) {
+	in.sendsMu.Lock()
+	defer in.sendsMu.Unlock()
+	if in.sends == nil {
+		in.sends = make(map[string]float64)
+	}
+	in.sends[bus] = gain
+}
+
+// send returns this input's send gain for bus.
+func (in *Input) send(bus string) float64 {
+	in.sendsMu.Lock()
+	defer in.sendsMu.Unlock()
+	if gain, ok := in.sends[bus]; ok {
+		return gain
+	}
+	if bus == defaultBus {
+		return 1
+	}
+	return 0
+}
+
+// silenced reports whether in should contribute nothing to the current
+// frame, either because it is explicitly muted or because anySoloed is
+// true and in is not one of the soloed inputs.
+func (in *Input) silenced(anySoloed bool) bool {
+	if in.isMuted() {
+		return true
+	}
+	return anySoloed && !in.isSoloed()
+}
+
+func boolToUint32(b bool) uint32 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// anySoloed reports whether at least one input in inputs is soloed.
+func anySoloed(inputs []*Input) bool {
+	for _, in := range inputs {
+		if in != nil && in.isSoloed() {
+			return true
+		}
+	}
+	return false
+}
+
+// applyPan scales buf's left/right channels in place according to an
+// equal-power pan law. buf is expected to be interleaved; channels other
+// than 2 are left untouched.
+func applyPan(buf signal.Floating, channels int, pan float64) {
+	if channels != 2 || pan == 0 {
+		return
+	}
+	angle := (pan + 1) * math.Pi / 4
+	l, r := math.Cos(angle), math.Sin(angle)
+	for i := 0; i+1 < buf.Len(); i += 2 {
+		buf.SetSample(i, buf.Sample(i)*l)
+		buf.SetSample(i+1, buf.Sample(i+1)*r)
+	}
+}
@@ -0,0 +1,137 @@
+package mixer
+
+import (
+	"context"
+	"math"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"pipelined.dev/signal"
+)
+
+// MeterFrame is a single aggregated metering reading emitted by a Meter's
+// Subscribe channel.
+type MeterFrame struct {
+	Peak float64
+	RMS  float64
+}
+
+const (
+	// peakReleasePerSecond is the rate, in dB/s, at which a Meter's peak
+	// reading decays towards silence once no louder sample has been seen.
+	peakReleasePerSecond = 12
+	// rmsWindow is the sliding window over which RMS is computed.
+	rmsWindow = 400 * time.Millisecond
+)
+
+// Meter computes rolling peak and RMS readings for a single input or for
+// the mixed output, without requiring callers to tap the signal path
+// themselves. It is fed samples from inside the mixer goroutine, which
+// already touches every one of them, so the added cost is one
+// multiply-accumulate and a max per sample.
+type Meter struct {
+	peakBits uint64 // math.Float64bits, accessed atomically
+	rmsBits  uint64 // math.Float64bits, accessed atomically
+
+	release   float64
+	window    []float64
+	windowSum float64
+	windowPos int
+	filled    int
+
+	closeOnce sync.Once
+	done      chan struct{}
+}
+
+func newMeter() *Meter {
+	return &Meter{done: make(chan struct{})}
+}
+
+// init sizes the RMS window and peak release coefficient for sampleRate.
+// It must be called once, from the mixer goroutine, before the first call
+// to update.
+func (m *Meter) init(sampleRate signal.SampleRate) {
+	size := int(rmsWindow.Seconds() * float64(sampleRate))
+	if size < 1 {
+		size = 1
+	}
+	m.window = make([]float64, size)
+	m.release = math.Pow(10, -peakReleasePerSecond/20/float64(sampleRate))
+}
+
+// update feeds a single sample into the meter. It is not safe for
+// concurrent use and must only be called from the mixer goroutine.
+func (m *Meter) update(sample float64) {
+	abs := math.Abs(sample)
+	if peak := m.Peak(); abs >= peak {
+		atomic.StoreUint64(&m.peakBits, math.Float64bits(abs))
+	} else if decayed := peak * m.release; decayed > abs {
+		atomic.StoreUint64(&m.peakBits, math.Float64bits(decayed))
+	} else {
+		atomic.StoreUint64(&m.peakBits, math.Float64bits(abs))
+	}
+
+	sq := sample * sample
+	m.windowSum += sq - m.window[m.windowPos]
+	m.window[m.windowPos] = sq
+	if m.windowPos++; m.windowPos == len(m.window) {
+		m.windowPos = 0
+	}
+	if m.filled < len(m.window) {
+		m.filled++
+	}
+	atomic.StoreUint64(&m.rmsBits, math.Float64bits(math.Sqrt(m.windowSum/float64(m.filled))))
+}
+
+// close terminates every channel returned by Subscribe.
+func (m *Meter) close() {
+	m.closeOnce.Do(func() { close(m.done) })
+}
+
+// Peak returns the current peak reading. It decays towards silence at
+// peakReleasePerSecond dB/s once no louder sample has been seen.
+func (m *Meter) Peak() float64 {
+	return math.Float64frombits(atomic.LoadUint64(&m.peakBits))
+}
+
+// RMS returns the root-mean-square level over the trailing rmsWindow.
+func (m *Meter) RMS() float64 {
+	return math.Float64frombits(atomic.LoadUint64(&m.rmsBits))
+}
+
+// Subscribe returns a channel that receives a MeterFrame with the current
+// Peak and RMS readings on every interval tick, until ctx is canceled or
+// the mixer's output closes, whichever happens first.
+func (m *Meter) Subscribe(ctx context.Context, interval time.Duration) <-chan MeterFrame {
+	frames := make(chan MeterFrame, 1)
+	go func() {
+		defer close(frames)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-m.done:
+				return
+			case <-ticker.C:
+				select {
+				case frames <- MeterFrame{Peak: m.Peak(), RMS: m.RMS()}:
+				default:
+				}
+			}
+		}
+	}()
+	return frames
+}
+
+// updateMeter feeds every sample of buf into meter, if meter is set.
+func updateMeter(meter *Meter, buf signal.Floating) {
+	if meter == nil {
+		return
+	}
+	for i := 0; i < buf.Len(); i++ {
+		meter.update(buf.Sample(i))
+	}
+}
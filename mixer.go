@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"io"
+	"sync"
 
 	"pipelined.dev/pipe"
 	"pipelined.dev/signal"
@@ -21,18 +22,30 @@ var (
 	ErrSinkFlushTimeout = errors.New("sink flush timeout")
 )
 
-// Mixer summs up multiple channels of messages into a single channel.
+// Mixer summs up multiple channels of messages into one or more output
+// buses.
 type Mixer struct {
 	sampleRate  signal.SampleRate
 	numChannels int
 
 	pool        *signal.Pool
 	inputSignal chan inputSignal
+	started     bool
 
-	head   *frame
-	frames []*frame
+	// busesMu guards buses: Bus and Sink can add buses/inputs from any
+	// goroutine, concurrently with the mixer goroutine ranging over buses.
+	busesMu sync.Mutex
+	buses   map[string]*busState
+	inputs  []*Input
+
+	resampler     ResamplerFunc
+	channelMatrix ChannelMatrix
+	sumMode       SumMode
 }
 
+// Option configures a Mixer at construction time.
+type Option func(m *Mixer)
+
 // frame represents a slice of samples to mix.
 type frame struct {
 	next     *frame
@@ -40,6 +53,7 @@ type frame struct {
 	expected int
 	added    int
 	flushed  int
+	muted    int
 }
 
 type inputSignal struct {
@@ -47,22 +61,50 @@ type inputSignal struct {
 	buffer signal.Floating
 }
 
-// sum returns mixed samplein.
-func (f *frame) sum() bool {
-	if f.added > 0 && f.added+f.flushed == f.expected {
+// sum finalizes the frame's buffer once every expected input has either
+// contributed, flushed or been muted, applying mode to combine the
+// contributing inputs. A frame whose only contributors were muted still
+// finalizes, emitting the silent buffer rather than being dropped; a frame
+// that every input flushed without contributing to is never emitted.
+func (f *frame) sum(mode SumMode) bool {
+	if f.added+f.flushed+f.muted != f.expected {
+		return false
+	}
+	if f.added == 0 && f.muted == 0 {
+		// every contributor flushed before sending anything.
+		return false
+	}
+	if f.added == 0 {
+		// every contributor was muted or flushed; buffer is already silent.
+		return true
+	}
+	switch mode {
+	case SumSaturate:
+		// samples are already summed, nothing left to do.
+	case SumClipHard:
+		for i := 0; i < f.buffer.Len(); i++ {
+			s := f.buffer.Sample(i)
+			switch {
+			case s > 1:
+				s = 1
+			case s < -1:
+				s = -1
+			}
+			f.buffer.SetSample(i, s)
+		}
+	default: // SumAverage
 		for i := 0; i < f.buffer.Len(); i++ {
 			f.buffer.SetSample(i, f.buffer.Sample(i)/float64(f.added))
 		}
-		return true
 	}
-	return false
+	return true
 }
 
-func (f *frame) add(in signal.Floating) {
+func (f *frame) add(in signal.Floating, gain float64) {
 	f.added++
 	length := min(f.buffer.Len(), in.Len())
 	for i := 0; i < length; i++ {
-		f.buffer.SetSample(i, f.buffer.Sample(i)+in.Sample(i))
+		f.buffer.SetSample(i, f.buffer.Sample(i)+in.Sample(i)*gain)
 	}
 	if f.buffer.Len() >= in.Len() {
 		return
@@ -70,110 +112,256 @@ func (f *frame) add(in signal.Floating) {
 
 	// todo: fix allocations here
 	for i := length; i < in.Len(); i++ {
-		f.buffer = f.buffer.AppendSample(in.Sample(i))
+		f.buffer = f.buffer.AppendSample(in.Sample(i) * gain)
 	}
 	return
 }
 
-func mixer(pool *signal.Pool, frames []*frame, input <-chan inputSignal, output chan<- signal.Floating) {
-	defer close(output)
-	activeInputs := len(frames)
+// mixer is the single goroutine consuming every input's buffers. It sums
+// each incoming buffer into every bus's current head frame, weighted by
+// that bus's send gain for the input, then advances per-bus heads
+// independently.
+func mixer(pool *signal.Pool, busesMu *sync.Mutex, buses map[string]*busState, inputs []*Input, sumMode SumMode, numChannels int, sampleRate signal.SampleRate, input <-chan inputSignal) {
+	defer func() {
+		busesMu.Lock()
+		defer busesMu.Unlock()
+		for _, b := range buses {
+			close(b.output)
+			b.meter.close()
+		}
+	}()
+	busesMu.Lock()
+	for _, b := range buses {
+		b.meter.init(sampleRate)
+	}
+	busesMu.Unlock()
+	for _, in := range inputs {
+		if in != nil {
+			in.meter.init(sampleRate)
+			defer in.meter.close()
+		}
+	}
+	activeInputs := len(inputs)
 	for {
 		if activeInputs == 0 {
 			return
 		}
 		is := <-input
-		f := frames[is.input]
 
 		// flush the signal
 		if is.buffer == nil {
-			frames[is.input] = nil
 			activeInputs--
-			for current := f; current != nil; current = current.next {
-				current.flushed++
-				if current.sum() {
-					output <- current.buffer
+			busesMu.Lock()
+			for _, b := range buses {
+				f := b.frames[is.input]
+				b.frames[is.input] = nil
+				for current := f; current != nil; current = current.next {
+					current.flushed++
+					if current.buffer == nil {
+						current.buffer = pool.GetFloat64()
+					}
+					if current.sum(sumMode) {
+						updateMeter(b.meter, current.buffer)
+						b.output <- current.buffer
+					}
 				}
 			}
+			busesMu.Unlock()
 			continue
 		}
 
-		if f.buffer == nil {
-			f.buffer = pool.GetFloat64()
-		}
-		f.add(is.buffer)
-		pool.PutFloat64(is.buffer)
-		if f.sum() {
-			output <- f.buffer
+		in := inputs[is.input]
+		muted := in != nil && in.silenced(anySoloed(inputs))
+		gain := 1.0
+		if in != nil {
+			gain = in.gain_()
+			applyPan(is.buffer, numChannels, in.pan_())
+			for i := 0; i < is.buffer.Len(); i++ {
+				if muted {
+					in.meter.update(0)
+				} else {
+					in.meter.update(is.buffer.Sample(i) * gain)
+				}
+			}
 		}
-		if f.next == nil {
-			// flushed sinks are not expected anymore
-			f.next = &frame{
-				expected: f.expected - f.flushed,
+		busesMu.Lock()
+		for name, b := range buses {
+			f := b.frames[is.input]
+			if f.buffer == nil {
+				f.buffer = pool.GetFloat64()
 			}
+			if muted {
+				f.muted++
+			} else {
+				send := 1.0
+				if in != nil {
+					send = in.send(name)
+				}
+				f.add(is.buffer, gain*send)
+			}
+			if f.sum(sumMode) {
+				updateMeter(b.meter, f.buffer)
+				b.output <- f.buffer
+			}
+			if f.next == nil {
+				// flushed sinks are not expected anymore
+				f.next = &frame{
+					expected: f.expected - f.flushed,
+				}
+			}
+			b.frames[is.input] = f.next
 		}
-		frames[is.input] = f.next
+		busesMu.Unlock()
+		pool.PutFloat64(is.buffer)
 	}
 }
 
-// New returns new mixer.
-func New(channels int) *Mixer {
-	return &Mixer{
+// New returns new mixer. It starts out with a single "main" bus, so
+// calling Source works exactly as it always did; additional buses can be
+// added with Bus.
+func New(channels int, options ...Option) *Mixer {
+	m := &Mixer{
 		numChannels: channels,
 		inputSignal: make(chan inputSignal, 1),
-		head:        &frame{},
+		buses:       map[string]*busState{defaultBus: newBusState(0)},
 	}
+	for _, option := range options {
+		option(m)
+	}
+	return m
 }
 
-// Source provides mixer source allocator. Mixer source outputs mixed
-// signal. Only single source per mixer is allowed.
-func (m *Mixer) Source() pipe.SourceAllocatorFunc {
-	return func(bufferSize int) (pipe.Source, pipe.SignalProperties, error) {
-		m.pool = signal.Allocator{
-			Channels: m.numChannels,
-			Capacity: bufferSize,
-		}.Pool()
-		outputSignal := make(chan signal.Floating, 1)
-		go mixer(m.pool, m.frames, m.inputSignal, outputSignal)
-
-		// this is needed to enable garbage collection
-		m.frames = nil
-		m.head = nil
-		return pipe.Source{
-				SourceFunc: func(out signal.Floating) (int, error) {
-					if sum, ok := <-outputSignal; ok {
-						defer m.pool.PutFloat64(sum)
-						return signal.FloatingAsFloating(sum, out), nil
-					}
-					return 0, io.EOF
-				},
-				FlushFunc: func(context.Context) error {
-					return nil
-				},
-			}, pipe.SignalProperties{
-				Channels:   m.numChannels,
-				SampleRate: m.sampleRate,
-			}, nil
+// start allocates the shared pool and launches the mixer goroutine the
+// first time any bus's allocator runs.
+func (m *Mixer) start(bufferSize int) {
+	if m.started {
+		return
 	}
+	m.started = true
+	m.pool = signal.Allocator{
+		Channels: m.numChannels,
+		Capacity: bufferSize,
+	}.Pool()
+	go mixer(m.pool, &m.busesMu, m.buses, m.inputs, m.sumMode, m.numChannels, m.sampleRate, m.inputSignal)
+	// this is needed to enable garbage collection
+	m.inputs = nil
 }
 
-// Sink provides mixer sink allocator. Mixer sink receives a signal for
-// mixing. Multiple sinks per mixer is allowed.
-func (m *Mixer) Sink() pipe.SinkAllocatorFunc {
+// Source provides the allocator and Meter for the default "main" bus.
+// Mixer source outputs mixed signal. Only a single source per bus is
+// allowed; use Bus for additional named outputs.
+func (m *Mixer) Source() (pipe.SourceAllocatorFunc, *Meter) {
+	return m.Bus(defaultBus)
+}
+
+// Bus returns the source allocator and Meter for the named output bus,
+// creating it if it doesn't exist yet. Every sink's SetSend controls how
+// much of that sink reaches this bus; sinks registered before Bus is
+// first called for name default to unity send on "main" and silence on
+// every other bus.
+//
+// All buses a Mixer will ever output must be created with Bus before the
+// first Source or Bus allocator runs: once the mixer goroutine has
+// started, a new bus would start out with no registered inputs, since the
+// input count it's built from is no longer tracked.
+func (m *Mixer) Bus(name string) (pipe.SourceAllocatorFunc, *Meter) {
+	m.busesMu.Lock()
+	b, ok := m.buses[name]
+	if !ok {
+		b = newBusState(len(m.inputs))
+		m.buses[name] = b
+	}
+	m.busesMu.Unlock()
+	return func(bufferSize int) (pipe.Source, pipe.SignalProperties, error) {
+			m.start(bufferSize)
+			return pipe.Source{
+					SourceFunc: func(out signal.Floating) (int, error) {
+						if sum, ok := <-b.output; ok {
+							defer m.pool.PutFloat64(sum)
+							return signal.FloatingAsFloating(sum, out), nil
+						}
+						return 0, io.EOF
+					},
+					FlushFunc: func(context.Context) error {
+						return nil
+					},
+				}, pipe.SignalProperties{
+					Channels:   m.numChannels,
+					SampleRate: m.sampleRate,
+				}, nil
+		}, b.meter
+}
+
+// Sink provides mixer sink allocator together with a handle controlling
+// this input's gain, mute, solo, pan and per-bus sends. Mixer sink
+// receives a signal for mixing. Multiple sinks per mixer is allowed.
+func (m *Mixer) Sink() (pipe.SinkAllocatorFunc, *Input) {
+	in := newInput()
 	return func(bufferSize int, props pipe.SignalProperties) (pipe.Sink, error) {
+		var resample bool
 		if m.sampleRate == 0 {
 			m.sampleRate = props.SampleRate
 		} else if m.sampleRate != props.SampleRate {
-			return pipe.Sink{}, ErrDifferentSampleRates
+			if m.resampler == nil {
+				return pipe.Sink{}, ErrDifferentSampleRates
+			}
+			resample = true
 		}
+		var adaptChannels bool
 		if m.numChannels != props.Channels {
-			return pipe.Sink{}, ErrDifferentChannels
+			matrix := m.channelMatrix
+			if matrix == nil {
+				matrix = defaultChannelMatrix(props.Channels, m.numChannels)
+			}
+			if matrix == nil {
+				return pipe.Sink{}, ErrDifferentChannels
+			}
+			adaptChannels = true
+		}
+		input := len(m.inputs)
+		m.inputs = append(m.inputs, in)
+		m.busesMu.Lock()
+		for _, b := range m.buses {
+			b.addInput()
+		}
+		m.busesMu.Unlock()
+		var resampler Resampler
+		var adapter *channelAdapter
+		send := func(ctx context.Context, buffer signal.Floating) error {
+			select {
+			case m.inputSignal <- inputSignal{input: input, buffer: buffer}:
+			case <-ctx.Done():
+				return ErrSinkFlushTimeout
+			}
+			return nil
+		}
+		// adapt applies the channel adapter, if this input needs one, to a
+		// buffer already at the mixer's sample rate. Both SinkFunc and the
+		// resampler's flushed tail (FlushFunc) must go through it, since
+		// either can hand the mixing loop a buffer with props.Channels
+		// channels instead of m.numChannels.
+		adapt := func(floats signal.Floating) signal.Floating {
+			if !adaptChannels {
+				return floats
+			}
+			if adapter == nil {
+				adapter = newChannelAdapter(m.pool, props.Channels, m.numChannels, m.channelMatrix)
+			}
+			return adapter.Adapt(floats)
 		}
-		input := len(m.frames)
-		m.frames = append(m.frames, m.head)
-		m.head.expected++
 		return pipe.Sink{
 			SinkFunc: func(floats signal.Floating) error {
+				if resample {
+					if resampler == nil {
+						resampler = m.resampler(m.pool, props.SampleRate, m.sampleRate, props.Channels)
+					}
+					converted, err := resampler.Resample(floats)
+					if err != nil {
+						return err
+					}
+					floats = converted
+				}
+				floats = adapt(floats)
 				// sink new buffer
 				inputBuffer := m.pool.GetFloat64().Slice(0, floats.Length())
 				copied := signal.FloatingAsFloating(floats, inputBuffer)
@@ -187,6 +375,17 @@ func (m *Mixer) Sink() pipe.SinkAllocatorFunc {
 				return nil
 			},
 			FlushFunc: func(ctx context.Context) error {
+				if resampler != nil {
+					tail, err := resampler.Flush()
+					if err != nil {
+						return err
+					}
+					if tail != nil {
+						if err := send(ctx, adapt(tail)); err != nil {
+							return err
+						}
+					}
+				}
 				select {
 				case m.inputSignal <- inputSignal{input: input}:
 				case <-ctx.Done():
@@ -195,7 +394,7 @@ func (m *Mixer) Sink() pipe.SinkAllocatorFunc {
 				return nil
 			},
 		}, nil
-	}
+	}, in
 }
 
 func min(n1, n2 int) int {
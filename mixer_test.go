@@ -2,6 +2,7 @@ package mixer_test
 
 import (
 	"context"
+	"io"
 	"reflect"
 	"testing"
 
@@ -11,6 +12,39 @@ import (
 	"pipelined.dev/signal"
 )
 
+// fixedRateSource is a minimal source with an explicit sample rate, used
+// to give an input a different rate than the rest of the mixer since
+// mock.Source doesn't expose one to vary per test.
+type fixedRateSource struct {
+	channels   int
+	sampleRate signal.SampleRate
+	values     []float64
+	pos        int
+}
+
+func (s *fixedRateSource) allocate(int) (pipe.Source, pipe.SignalProperties, error) {
+	return pipe.Source{
+			SourceFunc: func(out signal.Floating) (int, error) {
+				if s.pos >= len(s.values) {
+					return 0, io.EOF
+				}
+				n := 0
+				for i := 0; i < out.Len() && s.pos < len(s.values); i++ {
+					out.SetSample(i, s.values[s.pos])
+					s.pos++
+					n++
+				}
+				return n, nil
+			},
+			FlushFunc: func(context.Context) error {
+				return nil
+			},
+		}, pipe.SignalProperties{
+			Channels:   s.channels,
+			SampleRate: s.sampleRate,
+		}, nil
+}
+
 func TestMixer(t *testing.T) {
 	type generator struct {
 		messages int
@@ -66,15 +100,17 @@ func TestMixer(t *testing.T) {
 				Limit:    gen.messages,
 				Value:    gen.value,
 			}
+			sinkAllocator, _ := mixer.Sink()
 			line, _ := pipe.Routing{
 				Source: sourceAllocator.Source(),
-				Sink:   mixer.Sink(),
+				Sink:   sinkAllocator,
 			}.Line(bufferSize)
 			lines = append(lines, line)
 		}
 		sink := mock.Sink{}
+		sourceAllocator, _ := mixer.Source()
 		line, _ := pipe.Routing{
-			Source: mixer.Source(),
+			Source: sourceAllocator,
 			Sink:   sink.Sink(),
 		}.Line(bufferSize)
 		lines = append(lines, line)
@@ -88,6 +124,237 @@ func TestMixer(t *testing.T) {
 	}
 }
 
+func TestMixerChannelAdapt(t *testing.T) {
+	const (
+		numChannels = 2
+		bufferSize  = 2
+	)
+	mono := mock.Source{
+		Channels: 1,
+		Limit:    8,
+		Value:    0.7,
+	}
+	stereo := mock.Source{
+		Channels: 2,
+		Limit:    6,
+		Value:    0.5,
+	}
+
+	m := mixer.New(numChannels)
+	monoSink, _ := m.Sink()
+	monoLine, _ := pipe.Routing{
+		Source: mono.Source(),
+		Sink:   monoSink,
+	}.Line(bufferSize)
+	stereoSink, _ := m.Sink()
+	stereoLine, _ := pipe.Routing{
+		Source: stereo.Source(),
+		Sink:   stereoSink,
+	}.Line(bufferSize)
+	sink := mock.Sink{}
+	masterSource, _ := m.Source()
+	outputLine, _ := pipe.Routing{
+		Source: masterSource,
+		Sink:   sink.Sink(),
+	}.Line(bufferSize)
+
+	err := pipe.New(context.Background(), pipe.WithLines(monoLine, stereoLine, outputLine)).Wait()
+	assertEqual(t, "error", err, nil)
+
+	expected := []float64{
+		0.6, 0.6, 0.6, 0.6, 0.6, 0.6, 0.6, 0.6, 0.6, 0.6, 0.6, 0.6,
+		0.7, 0.7, 0.7, 0.7,
+	}
+	result := make([]float64, sink.Values.Len())
+	signal.ReadFloat64(sink.Values, result)
+	assertEqual(t, "result", result, expected)
+}
+
+// TestMixerResampleChannelMismatch covers an input that needs both
+// resampling and channel adaptation: the resampler must be built with the
+// input's own channel count, not the mixer's, or it misreads the
+// interleaved buffer before the channel adapter ever runs.
+func TestMixerResampleChannelMismatch(t *testing.T) {
+	const (
+		numChannels = 2
+		bufferSize  = 2
+	)
+	var gotChannels int
+	spyResample := func(pool *signal.Pool, inRate, outRate signal.SampleRate, channels int) mixer.Resampler {
+		gotChannels = channels
+		return mixer.LinearResampler()(pool, inRate, outRate, channels)
+	}
+
+	m := mixer.New(numChannels, mixer.WithResample(spyResample))
+
+	stereo := mock.Source{Channels: numChannels, Limit: 4, Value: 0.1}
+	stereoSink, _ := m.Sink()
+	stereoLine, _ := pipe.Routing{
+		Source: stereo.Source(),
+		Sink:   stereoSink,
+	}.Line(bufferSize)
+
+	mono := &fixedRateSource{channels: 1, sampleRate: 44100, values: []float64{0.2, 0.2, 0.2, 0.2}}
+	monoSink, _ := m.Sink()
+	monoLine, _ := pipe.Routing{
+		Source: mono.allocate,
+		Sink:   monoSink,
+	}.Line(bufferSize)
+
+	sink := mock.Sink{}
+	masterSource, _ := m.Source()
+	outputLine, _ := pipe.Routing{
+		Source: masterSource,
+		Sink:   sink.Sink(),
+	}.Line(bufferSize)
+
+	err := pipe.New(context.Background(), pipe.WithLines(stereoLine, monoLine, outputLine)).Wait()
+	assertEqual(t, "error", err, nil)
+	assertEqual(t, "resampler channels", gotChannels, mono.channels)
+}
+
+func TestMixerMute(t *testing.T) {
+	const (
+		numChannels = 1
+		bufferSize  = 2
+	)
+	loud := mock.Source{Channels: numChannels, Limit: 4, Value: 0.8}
+	quiet := mock.Source{Channels: numChannels, Limit: 4, Value: 0.2}
+
+	m := mixer.New(numChannels)
+	loudSink, loudInput := m.Sink()
+	loudInput.SetMute(true)
+	loudLine, _ := pipe.Routing{
+		Source: loud.Source(),
+		Sink:   loudSink,
+	}.Line(bufferSize)
+	quietSink, _ := m.Sink()
+	quietLine, _ := pipe.Routing{
+		Source: quiet.Source(),
+		Sink:   quietSink,
+	}.Line(bufferSize)
+	sink := mock.Sink{}
+	masterSource, _ := m.Source()
+	outputLine, _ := pipe.Routing{
+		Source: masterSource,
+		Sink:   sink.Sink(),
+	}.Line(bufferSize)
+
+	err := pipe.New(context.Background(), pipe.WithLines(loudLine, quietLine, outputLine)).Wait()
+	assertEqual(t, "error", err, nil)
+
+	expected := []float64{0.2, 0.2, 0.2, 0.2}
+	result := make([]float64, sink.Values.Len())
+	signal.ReadFloat64(sink.Values, result)
+	assertEqual(t, "result", result, expected)
+}
+
+func TestMixerMuteOnlyInput(t *testing.T) {
+	const (
+		numChannels = 1
+		bufferSize  = 2
+	)
+	gen := mock.Source{Channels: numChannels, Limit: 4, Value: 0.8}
+
+	m := mixer.New(numChannels)
+	genSink, input := m.Sink()
+	input.SetMute(true)
+	genLine, _ := pipe.Routing{
+		Source: gen.Source(),
+		Sink:   genSink,
+	}.Line(bufferSize)
+	sink := mock.Sink{}
+	masterSource, _ := m.Source()
+	outputLine, _ := pipe.Routing{
+		Source: masterSource,
+		Sink:   sink.Sink(),
+	}.Line(bufferSize)
+
+	err := pipe.New(context.Background(), pipe.WithLines(genLine, outputLine)).Wait()
+	assertEqual(t, "error", err, nil)
+
+	expected := []float64{0, 0, 0, 0}
+	result := make([]float64, sink.Values.Len())
+	signal.ReadFloat64(sink.Values, result)
+	assertEqual(t, "result", result, expected)
+}
+
+func TestMixerMeter(t *testing.T) {
+	const (
+		numChannels = 1
+		bufferSize  = 2
+	)
+	gen := mock.Source{Channels: numChannels, Limit: 8, Value: 0.5}
+
+	m := mixer.New(numChannels)
+	genSink, input := m.Sink()
+	genLine, _ := pipe.Routing{
+		Source: gen.Source(),
+		Sink:   genSink,
+	}.Line(bufferSize)
+	sink := mock.Sink{}
+	masterSource, masterMeter := m.Source()
+	outputLine, _ := pipe.Routing{
+		Source: masterSource,
+		Sink:   sink.Sink(),
+	}.Line(bufferSize)
+
+	err := pipe.New(context.Background(), pipe.WithLines(genLine, outputLine)).Wait()
+	assertEqual(t, "error", err, nil)
+
+	if peak := input.Meter().Peak(); peak != 0.5 {
+		t.Fatalf("input peak: got %v, want 0.5", peak)
+	}
+	if peak := masterMeter.Peak(); peak != 0.5 {
+		t.Fatalf("master peak: got %v, want 0.5", peak)
+	}
+}
+
+func TestMixerBuses(t *testing.T) {
+	const (
+		numChannels = 1
+		bufferSize  = 2
+	)
+	gen := mock.Source{Channels: numChannels, Limit: 4, Value: 0.4}
+
+	m := mixer.New(numChannels)
+	genSink, input := m.Sink()
+	input.SetSend("monitor", 0.5)
+	genLine, _ := pipe.Routing{
+		Source: gen.Source(),
+		Sink:   genSink,
+	}.Line(bufferSize)
+
+	// Bus must be called for every named bus before any bus's allocator
+	// runs and starts the mixer goroutine, so "monitor" is registered here
+	// before mainLine is built below.
+	monitorSink := mock.Sink{}
+	monitorSource, _ := m.Bus("monitor")
+
+	mainSink := mock.Sink{}
+	mainSource, _ := m.Source()
+	mainLine, _ := pipe.Routing{
+		Source: mainSource,
+		Sink:   mainSink.Sink(),
+	}.Line(bufferSize)
+
+	monitorLine, _ := pipe.Routing{
+		Source: monitorSource,
+		Sink:   monitorSink.Sink(),
+	}.Line(bufferSize)
+
+	err := pipe.New(context.Background(), pipe.WithLines(genLine, mainLine, monitorLine)).Wait()
+	assertEqual(t, "error", err, nil)
+
+	mainResult := make([]float64, mainSink.Values.Len())
+	signal.ReadFloat64(mainSink.Values, mainResult)
+	assertEqual(t, "main", mainResult, []float64{0.4, 0.4, 0.4, 0.4})
+
+	monitorResult := make([]float64, monitorSink.Values.Len())
+	signal.ReadFloat64(monitorSink.Values, monitorResult)
+	assertEqual(t, "monitor", monitorResult, []float64{0.2, 0.2, 0.2, 0.2})
+}
+
 func assertEqual(t *testing.T, name string, result, expected interface{}) {
 	t.Helper()
 	if !reflect.DeepEqual(expected, result) {
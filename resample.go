@@ -0,0 +1,127 @@
+package mixer
+
+import (
+	"math"
+
+	"pipelined.dev/signal"
+)
+
+// Resampler converts a signal sampled at one rate into a signal sampled at
+// another rate. A Resampler is allocated per input so that its state
+// (previous samples, fractional phase) is never shared between inputs.
+type Resampler interface {
+	// Resample converts in and returns the converted buffer. The returned
+	// buffer is allocated from the pool passed to the ResamplerFunc that
+	// created the Resampler.
+	Resample(in signal.Floating) (signal.Floating, error)
+	// Flush returns any samples buffered inside the resampler because
+	// they did not yet form a full output sample, or nil if there is
+	// nothing left to drain.
+	Flush() (signal.Floating, error)
+}
+
+// ResamplerFunc builds a Resampler converting from inRate to outRate for a
+// single input with the given number of channels. Buffers returned by the
+// resulting Resampler must be allocated from pool.
+type ResamplerFunc func(pool *signal.Pool, inRate, outRate signal.SampleRate, channels int) Resampler
+
+// WithResample enables automatic sample-rate conversion. When set, Sink no
+// longer rejects inputs whose sample rate differs from the mixer's rate:
+// instead every mismatched input is wrapped with a Resampler built by fn,
+// converting it to the mixer's rate before it reaches the mixing loop.
+func WithResample(fn ResamplerFunc) Option {
+	return func(m *Mixer) {
+		m.resampler = fn
+	}
+}
+
+// LinearResampler returns a ResamplerFunc producing a fast, allocation-light
+// linear interpolator implemented in pure Go. It trades quality for speed;
+// callers that need a higher quality conversion (e.g. a CGo libsoxr
+// binding) can supply their own ResamplerFunc to WithResample instead.
+func LinearResampler() ResamplerFunc {
+	return func(pool *signal.Pool, inRate, outRate signal.SampleRate, channels int) Resampler {
+		return &linearResampler{
+			pool:     pool,
+			channels: channels,
+			ratio:    float64(inRate) / float64(outRate),
+		}
+	}
+}
+
+// linearResampler linearly interpolates between consecutive input frames.
+// pos tracks the fractional read position in the input stream in frames;
+// prev holds the last frame of the previous call so interpolation is
+// continuous across SinkFunc invocations.
+type linearResampler struct {
+	pool     *signal.Pool
+	channels int
+	ratio    float64
+	pos      float64
+	prev     []float64
+	hasPrev  bool
+}
+
+func (r *linearResampler) frame(in signal.Floating, frame, channel int) float64 {
+	return in.Sample(frame*r.channels + channel)
+}
+
+func (r *linearResampler) Resample(in signal.Floating) (signal.Floating, error) {
+	inFrames := in.Length() / r.channels
+	out := r.pool.GetFloat64().Slice(0, 0)
+	for {
+		// r.pos can be negative right after a buffer boundary, when the
+		// interpolation still owes samples to the tail of the previous
+		// call's buffer; int() truncates towards zero instead of
+		// flooring, so it must not be used here.
+		frame := int(math.Floor(r.pos))
+		frac := r.pos - float64(frame)
+		if frame+1 >= inFrames {
+			break
+		}
+		for c := 0; c < r.channels; c++ {
+			var left float64
+			if frame < 0 {
+				left = r.prev[c]
+			} else {
+				left = r.frame(in, frame, c)
+			}
+			var right float64
+			if frame+1 < 0 {
+				right = r.prev[c]
+			} else {
+				right = r.frame(in, frame+1, c)
+			}
+			out = out.AppendSample(left + (right-left)*frac)
+		}
+		r.pos += r.ratio
+	}
+	r.pos -= float64(inFrames)
+	if inFrames > 0 {
+		r.prev = make([]float64, r.channels)
+		for c := 0; c < r.channels; c++ {
+			r.prev[c] = r.frame(in, inFrames-1, c)
+		}
+		r.hasPrev = true
+	}
+	return out, nil
+}
+
+// Flush drains the trailing partial frame, if any samples are still owed
+// to the output because the input ended mid-interpolation.
+func (r *linearResampler) Flush() (signal.Floating, error) {
+	if !r.hasPrev {
+		return nil, nil
+	}
+	out := r.pool.GetFloat64().Slice(0, 0)
+	for r.pos < 1 {
+		for c := 0; c < r.channels; c++ {
+			out = out.AppendSample(r.prev[c])
+		}
+		r.pos += r.ratio
+	}
+	if out.Length() == 0 {
+		return nil, nil
+	}
+	return out, nil
+}
@@ -0,0 +1,69 @@
+package mixer_test
+
+import (
+	"testing"
+
+	"pipelined.dev/mixer"
+	"pipelined.dev/signal"
+)
+
+func buildFloating(pool *signal.Pool, values ...float64) signal.Floating {
+	buf := pool.GetFloat64().Slice(0, 0)
+	for _, v := range values {
+		buf = buf.AppendSample(v)
+	}
+	return buf
+}
+
+func readFloating(buf signal.Floating) []float64 {
+	result := make([]float64, buf.Len())
+	for i := range result {
+		result[i] = buf.Sample(i)
+	}
+	return result
+}
+
+// TestLinearResamplerBoundaryContinuity feeds two buffers whose ratio
+// leaves a fractional carry across the call boundary, and whose values
+// jump sharply from one buffer to the next. If the resampler loses track
+// of the previous buffer's tail (e.g. by flooring the fractional position
+// incorrectly), the first sample of the second buffer interpolates
+// between the new buffer's own samples instead of between the old tail
+// and the new head, producing a visibly wrong value.
+func TestLinearResamplerBoundaryContinuity(t *testing.T) {
+	const channels = 1
+	pool := signal.Allocator{Channels: channels, Capacity: 8}.Pool()
+	r := mixer.LinearResampler()(pool, 3, 2, channels)
+
+	first, err := r.Resample(buildFloating(pool, 0, 0, 0, 0, 0))
+	if err != nil {
+		t.Fatalf("Resample: %v", err)
+	}
+	second, err := r.Resample(buildFloating(pool, 100, 100, 100, 100, 100))
+	if err != nil {
+		t.Fatalf("Resample: %v", err)
+	}
+
+	result := append(readFloating(first), readFloating(second)...)
+	expected := []float64{0, 0, 0, 50, 100, 100}
+	assertEqual(t, "result", result, expected)
+}
+
+func TestLinearResamplerFlush(t *testing.T) {
+	const channels = 1
+	pool := signal.Allocator{Channels: channels, Capacity: 8}.Pool()
+	r := mixer.LinearResampler()(pool, 3, 2, channels)
+
+	if _, err := r.Resample(buildFloating(pool, 0, 0, 0, 0, 0)); err != nil {
+		t.Fatalf("Resample: %v", err)
+	}
+	if _, err := r.Resample(buildFloating(pool, 100, 100, 100, 100, 100)); err != nil {
+		t.Fatalf("Resample: %v", err)
+	}
+
+	tail, err := r.Flush()
+	if err != nil {
+		t.Fatalf("Flush: %v", err)
+	}
+	assertEqual(t, "tail", readFloating(tail), []float64{100, 100})
+}